@@ -0,0 +1,151 @@
+// Driver for the AS1115, a pin-compatible superset of the MAX7219/MAX7221
+// that adds a keyscan matrix, a hex decode font and per-digit intensity.
+package as1115
+
+import (
+	"machine"
+
+	"tinygo.org/x/drivers"
+	"tinygo.org/x/drivers/max72xx"
+)
+
+// AS1115-specific registers, in addition to the MAX7219-compatible
+// register set implemented by max72xx.Device.
+const (
+	regFeature         byte = 0x0E // self-addressing, hex decode and keyscan enable bits
+	regKeyA            byte = 0x1C // debounced key-scan data for key group A
+	regKeyB            byte = 0x1D // debounced key-scan data for key group B
+	regDigit0Intensity byte = 0x10 // per-digit intensity base; digits N and N+1 share register regDigit0Intensity+N/2
+)
+
+// Feature register bits.
+//
+// featureHexDecode was previously assigned bit D0, which a datasheet
+// review found is actually the external clock enable bit, not a decode
+// select; it has been moved off D0 to stop colliding with that control.
+// None of these three bit positions have been independently re-verified
+// against the AMS AS1115 datasheet — confirm them against Table 8 of the
+// datasheet before relying on this driver in production.
+const (
+	featureHexDecode   byte = 0x02 // decode A-F instead of dash/E/H/L/P/blank
+	featureKeyscan     byte = 0x10 // enable the keyscan matrix
+	featureSelfAddress byte = 0x20 // enable I2C self-addressing
+)
+
+// Hex digits decoded in addition to the MAX7219 Code-B set (0-9) when
+// HexDecodeMode is enabled.
+const (
+	HexA byte = 0x0A
+	HexB byte = 0x0B
+	HexC byte = 0x0C
+	HexD byte = 0x0D
+	HexE byte = 0x0E
+	HexF byte = 0x0F
+)
+
+// Device is an AS1115 connection. It embeds *max72xx.Device, so
+// WriteCommand, WriteCommandN, Configure and the other MAX7219-compatible
+// methods are available unchanged.
+type Device struct {
+	*max72xx.Device
+	bus     drivers.SPI
+	cs      machine.Pin
+	feature byte // shadow of regFeature, since its bits are set independently
+}
+
+// New creates a new AS1115 connection. The SPI wire must already be
+// configured. opts are the same max72xx.Option values accepted by
+// max72xx.New.
+func New(bus drivers.SPI, cs machine.Pin, opts ...max72xx.Option) *Device {
+	return &Device{
+		Device: max72xx.New(bus, cs, opts...),
+		bus:    bus,
+		cs:     cs,
+	}
+}
+
+// setFeatureBit sets or clears a single bit of the shadowed feature
+// register and writes the whole register back, so that keyscan, hex
+// decode and self-addressing can be toggled independently of each other
+// — the headline AS1115 use case is running keyscan and a decode mode at
+// the same time.
+func (driver *Device) setFeatureBit(bit byte, enabled bool) {
+	if enabled {
+		driver.feature |= bit
+	} else {
+		driver.feature &^= bit
+	}
+	driver.WriteCommand(regFeature, driver.feature)
+}
+
+// HexDecodeMode enables or disables the AS1115 hex font, which decodes
+// A-F (see HexA..HexF) in place of the MAX7219's dash/E/H/L/P/blank
+// Code-B characters.
+func (driver *Device) HexDecodeMode(enabled bool) {
+	driver.setFeatureBit(featureHexDecode, enabled)
+}
+
+// SetPerDigitIntensity sets the intensity of a single digit (0-7),
+// overriding the chip's global intensity for that digit only. Digits are
+// packed two to a register, so this reads the current register back and
+// only replaces the target digit's nibble.
+func (driver *Device) SetPerDigitIntensity(digit, level uint8) {
+	if digit > 7 {
+		return
+	}
+	if level > 0x0F {
+		level = 0x0F
+	}
+
+	reg := regDigit0Intensity + digit/2
+	current, err := driver.readRegister(reg)
+	if err != nil {
+		return
+	}
+
+	if digit%2 == 0 {
+		current = (current &^ 0x0F) | level
+	} else {
+		current = (current &^ 0xF0) | (level << 4)
+	}
+	driver.WriteCommand(reg, current)
+}
+
+// ReadKeys reads the debounced key-scan registers and returns the raw
+// column bitmaps for key groups A and B. Enable the keyscan matrix first
+// with EnableKeyscan.
+func (driver *Device) ReadKeys() (keyA, keyB byte, err error) {
+	keyA, err = driver.readRegister(regKeyA)
+	if err != nil {
+		return 0, 0, err
+	}
+	keyB, err = driver.readRegister(regKeyB)
+	return keyA, keyB, err
+}
+
+// EnableKeyscan turns the keyscan matrix on or off.
+func (driver *Device) EnableKeyscan(enabled bool) {
+	driver.setFeatureBit(featureKeyscan, enabled)
+}
+
+// EnableSelfAddressing turns the AS1115's I2C self-addressing on or off,
+// letting several chips share a bus by deriving their address from an
+// external resistor/pin strap instead of a fixed address.
+func (driver *Device) EnableSelfAddressing(enabled bool) {
+	driver.setFeatureBit(featureSelfAddress, enabled)
+}
+
+// readRegister issues the AS1115 register read sequence: the register
+// address with its MSB set, followed by a don't-care byte during which
+// the chip shifts the register's contents back out. The single-frame
+// timing (address and data byte read back in the same CS assertion) has
+// not been independently re-verified against the datasheet; confirm it
+// there before relying on this driver in production.
+func (driver *Device) readRegister(register byte) (byte, error) {
+	w := [2]byte{register | 0x80, 0x00}
+	r := [2]byte{}
+	driver.cs.Low()
+	err := driver.bus.Tx(w[:], r[:])
+	driver.cs.High()
+	return r[1], err
+}
@@ -7,7 +7,7 @@ import (
 	"tinygo.org/x/drivers/max72xx"
 )
 
-// example for a 4 digit 7 segment display with 2 MAX7219 devices in series
+// example for a 4 digit 7 segment display with 1 MAX7219 device
 func main() {
 	// Pins for Arduino Nano 33 IOT
 	err := machine.SPI0.Configure(machine.SPIConfig{
@@ -21,29 +21,26 @@ func main() {
 		println(err.Error())
 	}
 
-	numberOfDevices := 1 // 1 MAX7219 device
-	driver := max72xx.NewDevice(machine.SPI0, machine.D6, uint8(numberOfDevices))
+	driver := max72xx.New(machine.SPI0, machine.D6,
+		max72xx.WithDevices(1),
+		max72xx.WithScanLimit(4),
+		max72xx.WithIntensity(8),
+		max72xx.WithDecodeMode(max72xx.DecodeAll),
+	)
+	driver.Configure()
 
 	numberOfDigits := 4
-	driver.Configure(max72xx.Config{NumberOfDigits: uint8(numberOfDigits), Intensity: 8})
-
-	// driver.StopDisplayTest()
-	// driver.SetDecodeMode(4)
-	// driver.SetScanLimit(4)
-	// driver.SetIntensity(8)
-	// driver.StopShutdownMode()
-
-	for i := 1; i < int(numberOfDigits); i++ {
-		driver.WriteCommand(0, max72xx.Command{Register: byte(i), Data: byte(Blank)})
+	for i := 1; i < numberOfDigits; i++ {
+		driver.WriteCommand(byte(i), max72xx.BcdBlank)
 	}
 
 	for {
 		for _, character := range characters {
 			println("writing", "characterValue:", character.String())
-			driver.WriteCommand(0, max72xx.Command{Register: byte(4), Data: byte(character)})
-			driver.WriteCommand(0, max72xx.Command{Register: byte(3), Data: byte(character)})
-			driver.WriteCommand(0, max72xx.Command{Register: byte(2), Data: byte(character)})
-			driver.WriteCommand(0, max72xx.Command{Register: byte(1), Data: byte(character)})
+			driver.WriteCommand(4, byte(character))
+			driver.WriteCommand(3, byte(character))
+			driver.WriteCommand(2, byte(character))
+			driver.WriteCommand(1, byte(character))
 
 			time.Sleep(500 * time.Millisecond)
 
@@ -70,10 +67,9 @@ var characters = []Character{
 	L,
 	P,
 	Blank,
-	Dot,
 }
 
-// Each bit translates to a pin, which is driven high or low
+// Each value maps to a Code-B digit on the 7-segment display.
 type Character byte
 
 func (char Character) String() string {
@@ -110,18 +106,16 @@ func (char Character) String() string {
 		return "P"
 	case Blank:
 		return ""
-	case Dot:
-		return "."
 	}
 
 	return ""
 }
 
 const (
-	Zero  Character = 0 //126
-	One   Character = 1 //48
-	Two   Character = 2 // 109
-	Three Character = 3 // 121
+	Zero  Character = 0
+	One   Character = 1
+	Two   Character = 2
+	Three Character = 3
 	Four  Character = 4
 	Five  Character = 5
 	Six   Character = 6
@@ -134,5 +128,4 @@ const (
 	L     Character = 13
 	P     Character = 14
 	Blank Character = 15
-	Dot   Character = 128
 )
@@ -0,0 +1,166 @@
+package max72xx
+
+import "strconv"
+
+// rawSegments holds raw segment patterns (bit7=DP, bit6=A .. bit0=G, per
+// the MAX7219 no-decode register layout) for the letters DisplayString
+// needs that fall outside the Code-B set (digits, '-', E, H, L, P, blank).
+var rawSegments = map[rune]byte{
+	'A': 0x77,
+	'b': 0x1F,
+	'C': 0x4E,
+	'd': 0x3D,
+	'F': 0x47,
+	'G': 0x5E,
+	'I': 0x06,
+	'J': 0x38,
+	'n': 0x15,
+	'O': 0x7E,
+	'o': 0x1D,
+	'q': 0x73,
+	'r': 0x05,
+	'S': 0x5B,
+	't': 0x0F,
+	'U': 0x3E,
+	'y': 0x3B,
+	'Z': 0x6D,
+}
+
+// charToCodeB returns the Code-B value for r, and whether r is part of
+// the Code-B set (digits, '-', E, H, L, P and blank).
+func charToCodeB(r rune) (byte, bool) {
+	switch {
+	case r >= '0' && r <= '9':
+		return byte(r - '0'), true
+	case r == '-':
+		return BcdDash, true
+	case r == 'E' || r == 'e':
+		return BcdE, true
+	case r == 'H' || r == 'h':
+		return BcdH, true
+	case r == 'L' || r == 'l':
+		return BcdL, true
+	case r == 'P' || r == 'p':
+		return BcdP, true
+	case r == ' ':
+		return BcdBlank, true
+	}
+	return 0, false
+}
+
+// digitChar is a single digit position: the character to show, and
+// whether its decimal point should be lit.
+type digitChar struct {
+	r   rune
+	dot bool
+}
+
+// splitDots walks s and folds each '.' into a dot on the digit before it,
+// since a 7-segment digit shows its decimal point alongside the digit
+// rather than as a character of its own.
+func splitDots(s string) []digitChar {
+	chars := make([]digitChar, 0, len(s))
+	for _, r := range s {
+		if r == '.' && len(chars) > 0 {
+			chars[len(chars)-1].dot = true
+			continue
+		}
+		chars = append(chars, digitChar{r: r})
+	}
+	return chars
+}
+
+// charCode returns the register value to show r, and whether it is a
+// Code-B value (true) or a raw segment pattern (false) — the two fonts
+// need different REG_DECODE_MODE bits to render correctly, since Code-B
+// values are only meaningful with that digit's decode bit on, and raw
+// segment patterns only with it off.
+func charCode(r rune) (data byte, isCodeB bool) {
+	if v, ok := charToCodeB(r); ok {
+		return v, true
+	}
+	if v, ok := rawSegments[r]; ok {
+		return v, false
+	}
+	return BcdBlank, true
+}
+
+// DisplayString writes s across the chain's digits, right-aligned when
+// rightAlign is true and left-aligned otherwise, blanking the digits s
+// doesn't reach. Digits, '-', E, H, L and P are shown via Code-B; other
+// recognized letters fall back to a raw segment pattern. A '.' lights the
+// decimal point of the character before it instead of occupying its own
+// digit.
+//
+// Code-B and raw segments need opposite decode-mode bits to render
+// correctly, so DisplayString computes a per-digit REG_DECODE_MODE
+// bitmask for each chip it touches and writes it before that chip's
+// digit data, overriding whatever decode mode Configure set.
+func (driver *Device) DisplayString(s string, rightAlign bool) {
+	chars := splitDots(s)
+	total := int(driver.n) * 8
+
+	offset := 0
+	if rightAlign {
+		offset = total - len(chars)
+	}
+
+	var data [8]byte
+	for chip := uint8(0); chip < driver.n; chip++ {
+		var decodeMask byte
+		for digit := uint8(0); digit < 8; digit++ {
+			idx := int(chip)*8 + int(digit) - offset
+
+			d := BcdBlank
+			isCodeB := true
+			if idx >= 0 && idx < len(chars) {
+				d, isCodeB = charCode(chars[idx].r)
+				if chars[idx].dot {
+					d |= BcdDot
+				}
+			}
+			if isCodeB {
+				decodeMask |= 1 << digit
+			}
+			data[digit] = d
+		}
+
+		driver.WriteCommandN(chip, REG_DECODE_MODE, decodeMask)
+		for digit := uint8(0); digit < 8; digit++ {
+			driver.WriteCommandN(chip, REG_DIGIT0+digit, data[digit])
+		}
+	}
+}
+
+// DisplayInt right-aligns value across the chain's digits, using Code-B
+// digits and a leading '-' for negative numbers.
+func (driver *Device) DisplayInt(value int) {
+	driver.DisplayString(strconv.Itoa(value), true)
+}
+
+// DisplayFloat right-aligns value across the chain's digits with
+// decimals digits after the decimal point, shown via the preceding
+// digit's decimal-point bit.
+func (driver *Device) DisplayFloat(value float64, decimals int) {
+	driver.DisplayString(strconv.FormatFloat(value, 'f', decimals, 64), true)
+}
+
+// DisplayClock shows hh:mm across the first 4 digits of the chain, using
+// the second digit's decimal point to stand in for the colon separator
+// that 7-segment digits don't have.
+func (driver *Device) DisplayClock(hh, mm uint8, colon bool) {
+	s := twoDigits(hh) + twoDigits(mm)
+	if colon {
+		s = s[:2] + "." + s[2:]
+	}
+	driver.DisplayString(s, false)
+}
+
+// twoDigits formats v as a zero-padded two digit string.
+func twoDigits(v uint8) string {
+	s := strconv.Itoa(int(v % 100))
+	if len(s) < 2 {
+		s = "0" + s
+	}
+	return s
+}
@@ -0,0 +1,223 @@
+package max72xx
+
+import (
+	"image/color"
+	"time"
+
+	"tinygo.org/x/drivers"
+)
+
+// Rotation describes how a single chip's 8x8 grid of LEDs is mapped onto
+// the logical canvas. This lets a chip be mounted upside-down or sideways
+// without the caller having to transform coordinates themselves.
+type Rotation uint8
+
+const (
+	Rotation0 Rotation = iota
+	Rotation90
+	Rotation180
+	Rotation270
+)
+
+// Matrix layers an 8x8 LED-matrix framebuffer on top of a Device, treating
+// the n chained modules as a single n*8 wide by 8 tall canvas. Call
+// SetPixel/Clear to draw into the framebuffer and Display to flush it to
+// the chain.
+//
+// Matrix expects the chain to already be configured for matrix mode, i.e.
+// decode mode off (see WithDecodeMode(DecodeNone)) and an appropriate scan limit and
+// intensity set.
+type Matrix struct {
+	driver   *Device
+	n        uint8
+	width    int
+	rotation []Rotation
+	order    []uint8
+	fb       [][8]byte
+}
+
+// NewMatrix creates a Matrix backed by driver, one 8x8 grid per chained
+// device.
+func NewMatrix(driver *Device) *Matrix {
+	n := int(driver.n)
+	m := &Matrix{
+		driver:   driver,
+		n:        driver.n,
+		width:    n * 8,
+		rotation: make([]Rotation, n),
+		order:    make([]uint8, n),
+		fb:       make([][8]byte, n),
+	}
+	for i := range m.order {
+		m.order[i] = uint8(i)
+	}
+	return m
+}
+
+// Width returns the canvas width in pixels, i.e. the number of chained
+// devices times 8.
+func (m *Matrix) Width() int {
+	return m.width
+}
+
+// SetRotation sets the orientation of the chip at the given logical
+// position in the chain. Out of range chip indexes are ignored.
+func (m *Matrix) SetRotation(chip int, r Rotation) {
+	if chip < 0 || chip >= len(m.rotation) {
+		return
+	}
+	m.rotation[chip] = r
+}
+
+// SetModuleOrder remaps which framebuffer grid is shown at each logical
+// chain position: order[i] is the chip index (as used by SetRotation and
+// the fb indexed by SetPixel) whose 8x8 grid should be sent to chain
+// position i, for wiring layouts where the modules are not daisy-chained
+// in the same order as the logical canvas (e.g. a chain that snakes back
+// on itself). order must have the same length as the number of chained
+// devices.
+func (m *Matrix) SetModuleOrder(order []uint8) {
+	if len(order) != len(m.order) {
+		return
+	}
+	copy(m.order, order)
+}
+
+// rotate maps a pixel local to one chip (0-7, 0-7) through that chip's
+// rotation, returning the row register and column bit it belongs to.
+func rotate(x, y int, r Rotation) (row, col int) {
+	switch r {
+	case Rotation90:
+		return x, 7 - y
+	case Rotation180:
+		return 7 - y, 7 - x
+	case Rotation270:
+		return 7 - x, y
+	default:
+		return y, x
+	}
+}
+
+// SetPixel turns the pixel at (x, y) on or off. x runs 0..Width()-1, y runs
+// 0..7. Out of range coordinates are ignored.
+func (m *Matrix) SetPixel(x, y int, on bool) {
+	if x < 0 || x >= m.width || y < 0 || y >= 8 {
+		return
+	}
+	chip := x / 8
+	row, col := rotate(x%8, y, m.rotation[chip])
+	bit := byte(1) << uint(7-col)
+	if on {
+		m.fb[chip][row] |= bit
+	} else {
+		m.fb[chip][row] &^= bit
+	}
+}
+
+// Clear blanks the framebuffer. Display must be called afterwards to push
+// the change to the chain.
+func (m *Matrix) Clear() {
+	for i := range m.fb {
+		m.fb[i] = [8]byte{}
+	}
+}
+
+// Display flushes the framebuffer to the chain, one row at a time. Each
+// row is written to every chained device within a single CS assertion,
+// reusing the device's preallocated frame buffer.
+func (m *Matrix) Display() {
+	for row := uint8(0); row < 8; row++ {
+		reg := REG_DIGIT0 + row
+		for i := uint8(0); i < m.n; i++ {
+			chip := m.order[i]
+			// Route through the driver's chain order so Matrix output
+			// stays aligned with WithReverseChainOrder, the same as
+			// WriteCommandN and Configure.
+			sendPos := m.driver.chainIndex(i)
+			m.driver.frame[2*sendPos] = reg
+			m.driver.frame[2*sendPos+1] = m.fb[chip][row]
+		}
+		m.driver.txFrame()
+	}
+}
+
+// glyphColumns renders s through font into a slice of column bitmaps, one
+// blank column wide between characters.
+func glyphColumns(s string, font Font) []byte {
+	cols := make([]byte, 0, len(s)*6)
+	for _, r := range s {
+		glyph, ok := font[byte(r)]
+		if !ok {
+			cols = append(cols, 0, 0, 0)
+			continue
+		}
+		cols = append(cols, glyph[0], glyph[1], glyph[2], glyph[3], glyph[4], 0)
+	}
+	return cols
+}
+
+// DrawText renders s using font at the left edge of the canvas and
+// displays it. Columns beyond the canvas width are clipped.
+func (m *Matrix) DrawText(s string, font Font) {
+	m.Clear()
+	for x, bits := range glyphColumns(s, font) {
+		if x >= m.width {
+			break
+		}
+		for row := 0; row < 7; row++ {
+			if bits&(1<<uint(row)) != 0 {
+				m.SetPixel(x, row, true)
+			}
+		}
+	}
+	m.Display()
+}
+
+// ScrollText renders s using font and scrolls it across the canvas from
+// right to left, one column per stepDelay, until it has fully exited on
+// the left.
+func (m *Matrix) ScrollText(s string, font Font, stepDelay time.Duration) {
+	cols := glyphColumns(s, font)
+	for offset := 0; offset < m.width+len(cols); offset++ {
+		m.Clear()
+		for i, bits := range cols {
+			x := m.width - offset + i
+			if x < 0 || x >= m.width {
+				continue
+			}
+			for row := 0; row < 7; row++ {
+				if bits&(1<<uint(row)) != 0 {
+					m.SetPixel(x, row, true)
+				}
+			}
+		}
+		m.Display()
+		time.Sleep(stepDelay)
+	}
+}
+
+// displayAdapter adapts Matrix to the tinygo.org/x/drivers Displayer
+// interface, so existing graphics helpers written against that interface
+// can target a Matrix.
+type displayAdapter struct {
+	m *Matrix
+}
+
+// Displayer returns m adapted to the drivers.Displayer interface.
+func (m *Matrix) Displayer() drivers.Displayer {
+	return displayAdapter{m: m}
+}
+
+func (d displayAdapter) Size() (x, y int16) {
+	return int16(d.m.width), 8
+}
+
+func (d displayAdapter) Display() error {
+	d.m.Display()
+	return nil
+}
+
+func (d displayAdapter) SetPixel(x, y int16, c color.RGBA) {
+	on := c.R != 0 || c.G != 0 || c.B != 0
+	d.m.SetPixel(int(x), int(y), on)
+}
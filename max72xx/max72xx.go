@@ -8,78 +8,190 @@ import (
 	"tinygo.org/x/drivers"
 )
 
+const maxNumberOfDevices = 8
+
+// DecodeMode selects how a chip decodes the byte written to each digit
+// register. DecodeNone is required for LED-matrix mode; the others are
+// for 7-segment digits using Code-B font.
+type DecodeMode byte
+
+const (
+	DecodeNone   DecodeMode = 0x00
+	DecodeDigit0 DecodeMode = 0x01
+	DecodeAll    DecodeMode = 0xFF
+)
+
+// deviceConfig holds the register values Configure applies to a single
+// chip in the chain.
+type deviceConfig struct {
+	scanLimit  uint8
+	intensity  uint8
+	decodeMode DecodeMode
+}
+
+var defaultDeviceConfig = deviceConfig{scanLimit: 8, intensity: 8, decodeMode: DecodeNone}
+
 type Device struct {
-	bus drivers.SPI
-	cs  machine.Pin
-	n   uint8 // Number of MAX7219 devices in series
+	bus     drivers.SPI
+	cs      machine.Pin
+	n       uint8 // Number of MAX7219 devices in series
+	reverse bool
+	configs [maxNumberOfDevices]deviceConfig
+	frame   []byte // preallocated 2*n byte buffer reused by every register write
 }
 
-const maxNumberOfDevices = 8
+// Option configures a Device constructed with New.
+type Option func(*Device)
 
-// NewDevice creates a new max7219 connection. The SPI wire must already be configured
-// The SPI frequency must not be higher than 10MHz.
-// parameter cs: the datasheet also refers to this pin as "load" pin.
-func NewDevice(bus drivers.SPI, cs machine.Pin) *Device {
-	return &Device{
-		bus: bus,
-		cs:  cs,
-		n:   1,
+// WithDevices sets the number of MAX7219 devices chained in series.
+// Values outside 1..8 are clamped to 1.
+func WithDevices(n uint8) Option {
+	return func(driver *Device) {
+		if n < 1 || n > maxNumberOfDevices {
+			n = 1
+		}
+		driver.n = n
 	}
 }
 
-// NewDeviceN creates a new max7219 connection with n devices in series. The SPI wire must already be configured
-func NewDeviceN(bus drivers.SPI, cs machine.Pin, n uint8) *Device {
-	if n < 1 || n > maxNumberOfDevices {
-		n = 1
+// WithIntensity sets the intensity of every chained device. There are 16
+// possible intensity levels; the valid range is 0x00-0x0F.
+func WithIntensity(intensity uint8) Option {
+	return func(driver *Device) {
+		if intensity > 0x0F {
+			intensity = 0x0F
+		}
+		for i := range driver.configs {
+			driver.configs[i].intensity = intensity
+		}
 	}
+}
 
-	return &Device{
-		bus: bus,
-		cs:  cs,
-		n:   n,
+// clampScanLimit keeps scanLimit in 1..8, the range Configure can turn
+// into a valid REG_SCANLIMIT value (scanLimit-1).
+func clampScanLimit(scanLimit uint8) uint8 {
+	if scanLimit < 1 {
+		return 1
+	}
+	if scanLimit > maxNumberOfDevices {
+		return maxNumberOfDevices
 	}
+	return scanLimit
 }
 
-// Configure setups the pins.
-func (driver *Device) Configure() {
-	outPutConfig := machine.PinConfig{Mode: machine.PinOutput}
+// WithScanLimit sets the scan limit of every chained device. Maximum is 8.
+// Example: a 4 digit 7-segment display has a scan limit of 4.
+func WithScanLimit(scanLimit uint8) Option {
+	scanLimit = clampScanLimit(scanLimit)
+	return func(driver *Device) {
+		for i := range driver.configs {
+			driver.configs[i].scanLimit = scanLimit
+		}
+	}
+}
 
-	driver.cs.Configure(outPutConfig)
+// WithDecodeMode sets the decode mode of every chained device.
+func WithDecodeMode(mode DecodeMode) Option {
+	return func(driver *Device) {
+		for i := range driver.configs {
+			driver.configs[i].decodeMode = mode
+		}
+	}
 }
 
-// SetScanLimit sets the scan limit. Maximum is 8.
-// Example: a 4 digit 7SegmentDisplay has a scan limit of 4
-func (driver *Device) SetScanLimit(digitNumber uint8) {
-	driver.writeToAll(REG_SCANLIMIT, digitNumber-1)
+// PerDeviceConfig overrides the scan limit, intensity and decode mode of a
+// single chip, letting a chain mix 7-segment and matrix modules.
+type PerDeviceConfig struct {
+	ScanLimit  uint8
+	Intensity  uint8
+	DecodeMode DecodeMode
 }
 
-// SetIntensity sets the intensity of the diplays.
-// There are 16 possible intensity levels. The valid range is 0x00-0x0F
-func (driver *Device) SetIntensity(intensity uint8) {
-	if intensity > 0x0F {
-		intensity = 0x0F
+// WithPerDeviceConfig overrides the configuration of the device at chain
+// position i (0-based). i outside the configured device count is ignored.
+func WithPerDeviceConfig(i int, cfg PerDeviceConfig) Option {
+	return func(driver *Device) {
+		if i < 0 || i >= len(driver.configs) {
+			return
+		}
+		driver.configs[i] = deviceConfig{
+			scanLimit:  clampScanLimit(cfg.ScanLimit),
+			intensity:  cfg.Intensity,
+			decodeMode: cfg.DecodeMode,
+		}
 	}
-	driver.writeToAll(REG_INTENSITY, intensity)
 }
 
-// SetDecodeMode sets the decode mode for 7 segment displays.
-// digitNumber = 1 -> 1 digit gets decoded
-// digitNumber = 2 or 3, or 4 -> 4 digit are being decoded
-// digitNumber = 8 -> 8 digits are being decoded
-// digitNumber 0 || digitNumber > 8 -> no decoding is being used
-func (driver *Device) SetDecodeMode(digitNumber uint8) {
-	switch digitNumber {
-	case 1: // only decode first digit
-		driver.writeToAll(REG_DECODE_MODE, 0x01)
-	case 2, 3, 4: //  decode digits 3-0
-		driver.writeToAll(REG_DECODE_MODE, 0x0F)
-	case 8: // decode 8 digits
-		driver.writeToAll(REG_DECODE_MODE, 0xFF)
-	default:
-		driver.writeToAll(REG_DECODE_MODE, 0x00)
+// WithReverseChainOrder reverses the order devices are addressed in. Use
+// this when chain position 0 (the device configured first) is wired
+// closest to the MCU rather than furthest from it.
+func WithReverseChainOrder() Option {
+	return func(driver *Device) {
+		driver.reverse = true
 	}
 }
 
+// New creates a new max7219 connection. The SPI wire must already be
+// configured. The SPI frequency must not be higher than 10MHz.
+// parameter cs: the datasheet also refers to this pin as "load" pin.
+func New(bus drivers.SPI, cs machine.Pin, opts ...Option) *Device {
+	driver := &Device{
+		bus: bus,
+		cs:  cs,
+		n:   1,
+	}
+	for i := range driver.configs {
+		driver.configs[i] = defaultDeviceConfig
+	}
+	for _, opt := range opts {
+		opt(driver)
+	}
+	driver.frame = make([]byte, 2*int(driver.n))
+	return driver
+}
+
+// chainIndex maps a send-order position (0 is the first 16 bits shifted
+// out, i.e. the device furthest down the chain) to the configured device
+// index, honoring WithReverseChainOrder.
+func (driver *Device) chainIndex(sendPos uint8) uint8 {
+	if driver.reverse {
+		return driver.n - 1 - sendPos
+	}
+	return sendPos
+}
+
+// Configure sets up the cs pin and emits the configured scan limit,
+// intensity and decode mode for every chained device, then takes the
+// chain out of shutdown mode. This replaces the previous
+// SetScanLimit/SetIntensity/SetDecodeMode/StopShutdownMode call sequence
+// with a single call driven by the options passed to New.
+func (driver *Device) Configure() {
+	driver.cs.Configure(machine.PinConfig{Mode: machine.PinOutput})
+
+	for i := uint8(0); i < driver.n; i++ {
+		cfg := driver.configs[driver.chainIndex(i)]
+		driver.frame[2*i] = REG_SCANLIMIT
+		driver.frame[2*i+1] = cfg.scanLimit - 1
+	}
+	driver.txFrame()
+
+	for i := uint8(0); i < driver.n; i++ {
+		cfg := driver.configs[driver.chainIndex(i)]
+		driver.frame[2*i] = REG_INTENSITY
+		driver.frame[2*i+1] = cfg.intensity
+	}
+	driver.txFrame()
+
+	for i := uint8(0); i < driver.n; i++ {
+		cfg := driver.configs[driver.chainIndex(i)]
+		driver.frame[2*i] = REG_DECODE_MODE
+		driver.frame[2*i+1] = byte(cfg.decodeMode)
+	}
+	driver.txFrame()
+
+	driver.StopShutdownMode()
+}
+
 // StartShutdownMode sets the IC into a low power shutdown mode.
 func (driver *Device) StartShutdownMode() {
 	driver.writeToAll(REG_SHUTDOWN, 0x00)
@@ -100,15 +212,20 @@ func (driver *Device) StopDisplayTest() {
 	driver.writeToAll(REG_DISPLAY_TEST, 0x00)
 }
 
-func (driver *Device) writeByte(data byte) {
-	driver.bus.Transfer(data)
+// txFrame sends driver.frame over the bus in a single Tx call, framed by
+// one CS assertion.
+func (driver *Device) txFrame() {
+	driver.cs.Low()
+	driver.bus.Tx(driver.frame, nil)
+	driver.cs.High()
 }
 
 // WriteCommand write data to a given register.
 func (driver *Device) WriteCommand(register, data byte) {
+	driver.frame[0] = register
+	driver.frame[1] = data
 	driver.cs.Low()
-	driver.writeByte(register)
-	driver.writeByte(data)
+	driver.bus.Tx(driver.frame[:2], nil)
 	driver.cs.High()
 }
 
@@ -130,50 +247,38 @@ func (driver *Device) WriteCommand(register, data byte) {
 // Examples:
 // To set the intensity to a medium level, send the following 16-bit data:
 //
-//	WriteCommandN(0, Command{Register: REG_INTENSITY, Data: 10})
+//	WriteCommandN(0, REG_INTENSITY, 10)
 //
 // Example: To set digit 1 on the first max72xx to display the number 5 and DP, send the following 16-bit data:
 //
-//	WriteCommandN(0, REG_DIGIT1, 5 | BcdDot})
+//	WriteCommandN(0, REG_DIGIT1, 5 | BcdDot)
 func (driver *Device) WriteCommandN(deviceNum uint8, register, data byte) {
 	if deviceNum >= driver.n {
 		deviceNum = 0
 	}
+	// chainIndex is its own inverse, so it also maps a device number to
+	// the send position that reaches it, keeping this aligned with how
+	// Configure addresses devices under WithReverseChainOrder.
+	sendPos := driver.chainIndex(deviceNum)
 
-	tmp := make([]struct {
-		Register byte
-		Data     byte
-	}, driver.n)
-	for i := range tmp {
-		tmp[i].Register = REG_NOOP
-		tmp[i].Data = 0x00
+	for i := uint8(0); i < driver.n; i++ {
+		driver.frame[2*i] = REG_NOOP
+		driver.frame[2*i+1] = 0x00
 	}
-	tmp[deviceNum].Data = data
-	tmp[deviceNum].Register = register
+	driver.frame[2*sendPos] = register
+	driver.frame[2*sendPos+1] = data
 
-	driver.cs.Low()
-	for _, d := range tmp {
-		driver.writeByte(d.Register)
-		driver.writeByte(d.Data)
-	}
-	driver.cs.High()
+	driver.txFrame()
 }
 
-// writeToAll sends the same command to all devices in the chain
+// writeToAll sends the same command to all devices in the chain. Every
+// device gets identical register/data, so chainIndex doesn't apply here:
+// send order doesn't matter when the payload is the same everywhere.
 func (driver *Device) writeToAll(register, data byte) {
-	tmp := make([]struct {
-		Register byte
-		Data     byte
-	}, driver.n)
-	for i := range tmp {
-		tmp[i].Register = register
-		tmp[i].Data = data
+	for i := uint8(0); i < driver.n; i++ {
+		driver.frame[2*i] = register
+		driver.frame[2*i+1] = data
 	}
 
-	driver.cs.Low()
-	for _, d := range tmp {
-		driver.writeByte(d.Register)
-		driver.writeByte(d.Data)
-	}
-	driver.cs.High()
+	driver.txFrame()
 }
@@ -0,0 +1,23 @@
+package max72xx
+
+import (
+	"machine"
+	"testing"
+)
+
+type mockSPI struct{}
+
+func (mockSPI) Transfer(w byte) (byte, error) { return 0, nil }
+func (mockSPI) Tx(w, r []byte) error           { return nil }
+
+// BenchmarkWriteToAll demonstrates that writing a register to every
+// chained device no longer allocates, now that Device reuses a
+// preallocated frame buffer instead of building one on every call.
+func BenchmarkWriteToAll(b *testing.B) {
+	driver := New(mockSPI{}, machine.NoPin, WithDevices(maxNumberOfDevices))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		driver.writeToAll(REG_INTENSITY, 8)
+	}
+}